@@ -0,0 +1,303 @@
+package testhelpers
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// SampleOptions configures how SampleResponseData fills in the fields it
+// can't pin down from the schema alone - string length when no
+// AllowedValues are declared, slice length, and how often optional fields
+// are included at all.
+type SampleOptions struct {
+	// OptionalFieldProbability is the chance, in [0, 1], that a non-required
+	// field is included in the generated data. Defaults to 0.5. A literal 0
+	// is indistinguishable from "unset", so to always exclude optional
+	// fields set ExcludeOptionalFields instead.
+	OptionalFieldProbability float64
+
+	// ExcludeOptionalFields, when true, omits every non-required field
+	// regardless of OptionalFieldProbability.
+	ExcludeOptionalFields bool
+
+	// StringLen is the length of generated strings that have no
+	// AllowedValues to draw from. Defaults to 8.
+	StringLen int
+
+	// MaxSliceLen bounds the length of generated slice/map fields. Defaults
+	// to 3. A literal 0 is indistinguishable from "unset", so to always
+	// force empty slices and maps set ForceEmptyCollections instead.
+	MaxSliceLen int
+
+	// ForceEmptyCollections, when true, generates empty slices and maps
+	// regardless of MaxSliceLen. A field whose jsonschema: override
+	// declares a minItems still gets that many entries.
+	ForceEmptyCollections bool
+}
+
+func (o SampleOptions) withDefaults() SampleOptions {
+	if o.ExcludeOptionalFields {
+		o.OptionalFieldProbability = 0
+	} else if o.OptionalFieldProbability == 0 {
+		o.OptionalFieldProbability = 0.5
+	}
+	if o.StringLen == 0 {
+		o.StringLen = 8
+	}
+	if o.ForceEmptyCollections {
+		o.MaxSliceLen = 0
+	} else if o.MaxSliceLen == 0 {
+		o.MaxSliceLen = 3
+	}
+	return o
+}
+
+// SampleResponseData generates a pseudorandom, schema-valid response data
+// map from schema.Fields: required fields are always populated, optional
+// fields are included per opts.OptionalFieldProbability, strings and
+// numbers prefer a field's AllowedValues when present, a "format:<name>"
+// annotation (see ValidateResponseWithFormats) is honored when present, and
+// the result passes ValidateResponseData(schema, data, true) for the same
+// seed every time. It is meant to give plugin tests broader field coverage
+// than a hand-written fixture, not to replace ValidateResponse - pair it
+// with QuickCheckResponse.
+func SampleResponseData(schema *framework.Response, seed int64, opts SampleOptions) map[string]interface{} {
+	if schema == nil {
+		return nil
+	}
+	opts = opts.withDefaults()
+
+	rnd := rand.New(rand.NewSource(seed))
+	data := map[string]interface{}{}
+
+	// Visit fields in a fixed order so a given seed always draws the same
+	// values for the same fields.
+	names := make([]string, 0, len(schema.Fields))
+	for name := range schema.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := schema.Fields[name]
+		if !field.Required && rnd.Float64() >= opts.OptionalFieldProbability {
+			continue
+		}
+		data[name] = sampleValue(rnd, field, opts)
+	}
+
+	return data
+}
+
+// sampleValue generates a single pseudorandom value conforming to field. A
+// field carrying a "jsonschema:" override annotation (see
+// fieldJSONSchemaOverride) is sampled from that document instead of
+// field.Type, so fixtures can't violate a minimum/maximum, minItems, or
+// nested item schema that the FieldType switch below has no way to express.
+func sampleValue(rnd *rand.Rand, field *framework.FieldSchema, opts SampleOptions) interface{} {
+	if len(field.AllowedValues) > 0 {
+		return field.AllowedValues[rnd.Intn(len(field.AllowedValues))]
+	}
+
+	if override, ok, err := fieldJSONSchemaOverride(field); err == nil && ok {
+		return sampleFromSchemaDoc(rnd, override, opts)
+	}
+
+	switch field.Type {
+	case framework.TypeString, framework.TypeNameString, framework.TypeLowerCaseString, framework.TypeHeader:
+		// Honor the "format:<name>" annotation ValidateResponseWithFormats
+		// checks, so generated fixtures don't fail their own format pass.
+		if format, ok := fieldFormatOf(field); ok {
+			return sampleFormattedString(rnd, format)
+		}
+		return sampleString(rnd, opts.StringLen)
+	case framework.TypeInt, framework.TypeInt64, framework.Type64BitUnsignedInteger:
+		return rnd.Intn(1 << 16)
+	case framework.TypeFloat:
+		return rnd.Float64() * 1000
+	case framework.TypeBool:
+		return rnd.Intn(2) == 0
+	case framework.TypeDurationSecond, framework.TypeSignedDurationSecond:
+		return rnd.Intn(86400)
+	case framework.TypeTime:
+		return time.Unix(rnd.Int63n(1<<31), 0).UTC().Format(time.RFC3339)
+	case framework.TypeMap, framework.TypeKVPairs:
+		m := map[string]interface{}{}
+		for i := 0; i < rnd.Intn(opts.MaxSliceLen+1); i++ {
+			m[sampleString(rnd, opts.StringLen)] = sampleString(rnd, opts.StringLen)
+		}
+		return m
+	case framework.TypeSlice:
+		s := make([]interface{}, rnd.Intn(opts.MaxSliceLen+1))
+		for i := range s {
+			s[i] = sampleString(rnd, opts.StringLen)
+		}
+		return s
+	case framework.TypeStringSlice, framework.TypeCommaStringSlice:
+		s := make([]string, rnd.Intn(opts.MaxSliceLen+1))
+		for i := range s {
+			s[i] = sampleString(rnd, opts.StringLen)
+		}
+		return s
+	case framework.TypeCommaIntSlice:
+		s := make([]int, rnd.Intn(opts.MaxSliceLen+1))
+		for i := range s {
+			s[i] = rnd.Intn(1 << 16)
+		}
+		return s
+	default:
+		return sampleString(rnd, opts.StringLen)
+	}
+}
+
+// sampleFromSchemaDoc generates a pseudorandom value conforming to doc, a raw
+// JSON Schema fragment as produced by fieldToJSONSchema - either a field's
+// "jsonschema:" override itself, or an "items"/"additionalProperties"
+// sub-schema nested inside one. It honors "enum", "minimum"/"maximum" on
+// integers, and "minItems" plus a nested "items" schema on arrays, which is
+// exactly the set of constraints a FieldType alone can't express.
+func sampleFromSchemaDoc(rnd *rand.Rand, doc map[string]interface{}, opts SampleOptions) interface{} {
+	if enum, ok := doc["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[rnd.Intn(len(enum))]
+	}
+
+	switch doc["type"] {
+	case "integer":
+		min, max := schemaIntBounds(doc)
+		return min + rnd.Intn(max-min+1)
+	case "number":
+		min, max := schemaIntBounds(doc)
+		return float64(min) + rnd.Float64()*float64(max-min)
+	case "boolean":
+		return rnd.Intn(2) == 0
+	case "array":
+		items, _ := doc["items"].(map[string]interface{})
+		n := schemaMinItems(doc) + rnd.Intn(opts.MaxSliceLen+1)
+		s := make([]interface{}, n)
+		for i := range s {
+			if items != nil {
+				s[i] = sampleFromSchemaDoc(rnd, items, opts)
+			} else {
+				s[i] = sampleString(rnd, opts.StringLen)
+			}
+		}
+		return s
+	case "object":
+		valueSchema, _ := doc["additionalProperties"].(map[string]interface{})
+		m := map[string]interface{}{}
+		for i := 0; i < rnd.Intn(opts.MaxSliceLen+1); i++ {
+			if valueSchema != nil {
+				m[sampleString(rnd, opts.StringLen)] = sampleFromSchemaDoc(rnd, valueSchema, opts)
+			} else {
+				m[sampleString(rnd, opts.StringLen)] = sampleString(rnd, opts.StringLen)
+			}
+		}
+		return m
+	default:
+		// "string", or a type this function doesn't special-case: the
+		// alphanumeric alphabet sampleString draws from already satisfies
+		// common patterns like "^[a-z0-9.-]+$", so fall back to it rather
+		// than implementing a pattern-matching string generator.
+		return sampleString(rnd, opts.StringLen)
+	}
+}
+
+// schemaIntBounds reads a JSON Schema document's "minimum"/"maximum"
+// keywords, defaulting to the same [0, 1<<16) range sampleValue uses for a
+// plain TypeInt field when either is absent.
+func schemaIntBounds(doc map[string]interface{}) (min, max int) {
+	min, max = 0, 1<<16-1
+	if v, ok := doc["minimum"].(float64); ok {
+		min = int(v)
+	}
+	if v, ok := doc["maximum"].(float64); ok {
+		max = int(v)
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+// schemaMinItems reads a JSON Schema document's "minItems" keyword, treating
+// it as 0 when absent.
+func schemaMinItems(doc map[string]interface{}) int {
+	if v, ok := doc["minItems"].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+const sampleAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+func sampleString(rnd *rand.Rand, length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = sampleAlphabet[rnd.Intn(len(sampleAlphabet))]
+	}
+	return string(b)
+}
+
+// sampleFormattedString generates a pseudorandom string that satisfies
+// validateFormat for format, so fields carrying a "format:<name>"
+// annotation round-trip through ValidateResponseWithFormats.
+func sampleFormattedString(rnd *rand.Rand, format fieldFormat) string {
+	switch format {
+	case formatUUID:
+		b := make([]byte, 16)
+		for i := range b {
+			b[i] = byte(rnd.Intn(256))
+		}
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case formatDateTime:
+		return time.Unix(rnd.Int63n(1<<31), 0).UTC().Format(time.RFC3339)
+	case formatDuration:
+		return (time.Duration(rnd.Intn(86400)) * time.Second).String()
+	case formatIPv4:
+		return fmt.Sprintf("%d.%d.%d.%d", rnd.Intn(256), rnd.Intn(256), rnd.Intn(256), rnd.Intn(256))
+	case formatIPv6:
+		ip := make(net.IP, 16)
+		for i := range ip {
+			ip[i] = byte(rnd.Intn(256))
+		}
+		return ip.String()
+	case formatCIDR:
+		return fmt.Sprintf("%d.%d.%d.%d/%d", rnd.Intn(256), rnd.Intn(256), rnd.Intn(256), rnd.Intn(256), rnd.Intn(33))
+	case formatEmail:
+		return fmt.Sprintf("%s@example.com", sampleString(rnd, 8))
+	case formatHostname:
+		return fmt.Sprintf("%s.example.com", sampleString(rnd, 8))
+	default:
+		return sampleString(rnd, 8)
+	}
+}
+
+// QuickCheckResponse generates iterations worth of schema-valid response
+// data via SampleResponseData, feeds each one through handler, and validates
+// the result against schema with ValidateResponse in strict mode. It exists
+// to catch handlers that mutate a field - adding an extra key, dropping a
+// required one, changing a type - in a way the schema doesn't admit, which a
+// single hand-written fixture would never exercise.
+func QuickCheckResponse(t *testing.T, schema *framework.Response, handler func(data map[string]interface{}) (*logical.Response, error), iterations int) {
+	t.Helper()
+
+	for i := 0; i < iterations; i++ {
+		data := SampleResponseData(schema, int64(i), SampleOptions{})
+
+		resp, err := handler(data)
+		if err != nil {
+			t.Fatalf("iteration %d: handler returned error for input %#v: %v", i, data, err)
+		}
+
+		if err := ValidateResponse(schema, resp, true); err != nil {
+			t.Fatalf("iteration %d: handler response failed schema validation for input %#v: %s", i, data, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func testWidgetResponseSchema() *framework.Response {
+	return &framework.Response{
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:     framework.TypeString,
+				Required: true,
+			},
+			"owner_email": {
+				Type:        framework.TypeString,
+				Required:    true,
+				Description: "The widget owner's email address. format:email",
+			},
+		},
+	}
+}
+
+func TestSampleResponseData_HonorsFormat(t *testing.T) {
+	schema := testWidgetResponseSchema()
+
+	for seed := int64(0); seed < 20; seed++ {
+		data := SampleResponseData(schema, seed, SampleOptions{})
+
+		resp := &logical.Response{Data: data}
+		if err := ValidateResponseWithFormats(schema, resp, true); err != nil {
+			t.Fatalf("seed %d: generated data failed format validation: %v\ndata: %#v", seed, err, data)
+		}
+	}
+}
+
+func TestSampleResponseData_ExplicitZeroOptions(t *testing.T) {
+	schema := testWidgetResponseSchema()
+	// owner_email is required in the shared widget schema; make it optional
+	// here so ExcludeOptionalFields has a field to omit.
+	schema.Fields["owner_email"].Required = false
+
+	opts := SampleOptions{ExcludeOptionalFields: true}
+	for seed := int64(0); seed < 20; seed++ {
+		data := SampleResponseData(schema, seed, opts)
+		if _, ok := data["owner_email"]; ok {
+			t.Fatalf("seed %d: expected ExcludeOptionalFields to omit owner_email, got %#v", seed, data)
+		}
+	}
+}
+
+func TestSampleResponseData_HonorsJSONSchemaOverride(t *testing.T) {
+	schema := &framework.Response{
+		Fields: map[string]*framework.FieldSchema{
+			"hosts": {
+				Type:        framework.TypeSlice,
+				Required:    true,
+				Description: `The target hosts. jsonschema:{"type":"array","items":{"type":"string","pattern":"^[a-z0-9.-]+$"},"minItems":1}`,
+			},
+		},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		data := SampleResponseData(schema, seed, SampleOptions{})
+
+		resp := &logical.Response{Data: data}
+		if err := ValidateResponseStrictJSONSchema(schema, resp); err != nil {
+			t.Fatalf("seed %d: generated data violated the jsonschema override: %v\ndata: %#v", seed, err, data)
+		}
+	}
+}
+
+func TestQuickCheckResponse_CatchesHandlerDrift(t *testing.T) {
+	schema := testWidgetResponseSchema()
+
+	mutatingHandler := func(data map[string]interface{}) (*logical.Response, error) {
+		// Simulate a handler bug: it drops a required field.
+		delete(data, "owner_email")
+		return &logical.Response{Data: data}, nil
+	}
+
+	// Run QuickCheckResponse in a subtest so its t.Fatalf on the expected
+	// violation doesn't abort this test too; t.Run reports whether the
+	// subtest passed.
+	passed := t.Run("quickcheck", func(st *testing.T) {
+		QuickCheckResponse(st, schema, mutatingHandler, 5)
+	})
+	if passed {
+		t.Error("expected QuickCheckResponse to catch a handler dropping a required field")
+	}
+}
@@ -0,0 +1,183 @@
+package testhelpers
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// fieldFormat is a declared string format convention for a response field,
+// on top of the Go-type conversion ValidateResponseData already checks.
+type fieldFormat string
+
+const (
+	formatUUID     fieldFormat = "uuid"
+	formatDateTime fieldFormat = "date-time"
+	formatDuration fieldFormat = "duration"
+	formatIPv4     fieldFormat = "ipv4"
+	formatIPv6     fieldFormat = "ipv6"
+	formatCIDR     fieldFormat = "cidr"
+	formatEmail    fieldFormat = "email"
+	formatHostname fieldFormat = "hostname"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// hostnamePattern follows RFC 1123: labels of alphanumerics and hyphens,
+// not starting or ending with a hyphen, joined by dots.
+var hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// formatAnnotation matches the format convention declared on a field, if it
+// has one. There is no dedicated metadata key on framework.FieldSchema for
+// this, so the convention is a trailing "format:<name>" annotation on the
+// field's Description, e.g. "The request ID. format:uuid".
+var formatAnnotation = regexp.MustCompile(`format:(\S+)\s*$`)
+
+// ValidateResponseWithFormats runs ValidateResponseData and then, for every
+// schema field carrying a "format:<name>" annotation in its Description,
+// additionally asserts that the field's value matches that format. Supported
+// formats are uuid, date-time (RFC3339), duration (Go duration string),
+// ipv4, ipv6, cidr, email, and hostname.
+//
+// Like ValidateResponseData, every violation - type, required, and now
+// format - is accumulated before returning; a non-nil error is a
+// ValidationErrors, except when ValidateResponseData fails to convert the
+// data to JSON, whose error is returned as is.
+//
+// This function is inefficient and is intended to be used in tests only.
+func ValidateResponseWithFormats(schema *framework.Response, response *logical.Response, strict bool) error {
+	var errs ValidationErrors
+
+	var data map[string]interface{}
+	if response != nil {
+		data = response.Data
+	}
+	if err := ValidateResponseData(schema, data, strict); err != nil {
+		ve, ok := err.(ValidationErrors)
+		if !ok {
+			return err
+		}
+		errs = append(errs, ve...)
+	}
+
+	if schema == nil || response == nil {
+		if len(errs) == 0 {
+			return nil
+		}
+		return errs
+	}
+
+	errs = append(errs, formatViolations(schema.Fields, response.Data)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// formatViolations checks every field in fields carrying a "format:<name>"
+// annotation against the corresponding value in data, the same way
+// ValidateResponseWithFormats does. ValidateRequest uses this too, so a
+// malformed "format:uuid"/"format:email"/... field is caught on both the
+// request and response side.
+func formatViolations(fields map[string]*framework.FieldSchema, data map[string]interface{}) ValidationErrors {
+	var errs ValidationErrors
+
+	for name, field := range fields {
+		format, ok := fieldFormatOf(field)
+		if !ok {
+			continue
+		}
+
+		value, ok := data[name]
+		if !ok {
+			continue
+		}
+
+		path := "/" + name
+
+		str, ok := value.(string)
+		if !ok {
+			errs = append(errs, &ValidationError{
+				Path:      path,
+				Keyword:   "format",
+				Expected:  fmt.Sprintf("a string matching format %q", format),
+				Got:       fmt.Sprintf("%T", value),
+				SpecField: field,
+			})
+			continue
+		}
+
+		if err := validateFormat(format, str); err != nil {
+			errs = append(errs, &ValidationError{
+				Path:      path,
+				Keyword:   "format",
+				Expected:  string(format),
+				Got:       str,
+				SpecField: field,
+			})
+		}
+	}
+
+	return errs
+}
+
+// fieldFormatOf extracts the declared format from a field's Description, per
+// the "format:<name>" convention documented on ValidateResponseWithFormats.
+func fieldFormatOf(field *framework.FieldSchema) (fieldFormat, bool) {
+	match := formatAnnotation.FindStringSubmatch(field.Description)
+	if match == nil {
+		return "", false
+	}
+	return fieldFormat(match[1]), true
+}
+
+// validateFormat asserts that value conforms to format.
+func validateFormat(format fieldFormat, value string) error {
+	switch format {
+	case formatUUID:
+		if !uuidPattern.MatchString(value) {
+			return fmt.Errorf("%q is not a valid uuid", value)
+		}
+	case formatDateTime:
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("%q is not a valid rfc3339 timestamp: %w", value, err)
+		}
+	case formatDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%q is not a valid duration: %w", value, err)
+		}
+	case formatIPv4:
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is not a valid ipv4 address", value)
+		}
+	case formatIPv6:
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is not a valid ipv6 address", value)
+		}
+	case formatCIDR:
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return fmt.Errorf("%q is not a valid cidr: %w", value, err)
+		}
+	case formatEmail:
+		if _, err := mail.ParseAddress(value); err != nil {
+			return fmt.Errorf("%q is not a valid email address: %w", value, err)
+		}
+	case formatHostname:
+		if len(value) > 253 || !hostnamePattern.MatchString(value) {
+			return fmt.Errorf("%q is not a valid hostname", value)
+		}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestValidateFormat(t *testing.T) {
+	cases := []struct {
+		format  fieldFormat
+		value   string
+		wantErr bool
+	}{
+		{formatUUID, "3f9a6b2e-8e0b-4f1a-9d1a-2c2b8a7c1e4d", false},
+		{formatUUID, "not-a-uuid", true},
+		{formatDateTime, "2024-01-02T15:04:05Z", false},
+		{formatDateTime, "2024-01-02", true},
+		{formatDuration, "30s", false},
+		{formatDuration, "thirty seconds", true},
+		{formatIPv4, "10.0.0.1", false},
+		{formatIPv4, "::1", true},
+		{formatIPv6, "::1", false},
+		{formatIPv6, "10.0.0.1", true},
+		{formatCIDR, "10.0.0.0/24", false},
+		{formatCIDR, "10.0.0.0", true},
+		{formatEmail, "user@example.com", false},
+		{formatEmail, "not-an-email", true},
+		{formatHostname, "vault.example.com", false},
+		{formatHostname, "not a hostname!", true},
+	}
+
+	for _, c := range cases {
+		err := validateFormat(c.format, c.value)
+		if c.wantErr && err == nil {
+			t.Errorf("format %q: expected %q to be rejected", c.format, c.value)
+		}
+		if !c.wantErr && err != nil {
+			t.Errorf("format %q: expected %q to be accepted, got %v", c.format, c.value, err)
+		}
+	}
+}
+
+func TestValidateResponseWithFormats(t *testing.T) {
+	schema := &framework.Response{
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:        framework.TypeString,
+				Required:    true,
+				Description: "The request ID. format:uuid",
+			},
+		},
+	}
+
+	resp := &logical.Response{
+		Data: map[string]interface{}{
+			"id": "not-a-uuid",
+		},
+	}
+
+	err := ValidateResponseWithFormats(schema, resp, true)
+	if err == nil {
+		t.Fatal("expected a format violation for a malformed uuid, got nil")
+	}
+
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	found := false
+	for _, e := range errs {
+		if e.Path == "/id" && e.Keyword == "format" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a /id format violation, got %v", errs)
+	}
+
+	resp.Data["id"] = "3f9a6b2e-8e0b-4f1a-9d1a-2c2b8a7c1e4d"
+	if err := ValidateResponseWithFormats(schema, resp, true); err != nil {
+		t.Errorf("expected a well-formed uuid to pass, got %v", err)
+	}
+}
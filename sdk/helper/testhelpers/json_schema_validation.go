@@ -0,0 +1,302 @@
+package testhelpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// jsonSchemaDraft is the dialect every document built by this file declares
+// itself against.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ValidateResponseStrictJSONSchema validates response against schema.Fields
+// the same way ValidateResponseData does, but instead of round-tripping the
+// data through framework.FieldData it translates the field schema into a
+// draft 2020-12 JSON Schema document and validates through
+// github.com/santhosh-tekuri/jsonschema. That buys nested object/array
+// structure, AllowedValues as an enum, and every violation in the response
+// rather than just the first one ValidateStrict happens to hit.
+//
+// This function is inefficient and is intended to be used in tests only.
+func ValidateResponseStrictJSONSchema(schema *framework.Response, response *logical.Response) error {
+	if schema == nil {
+		return nil
+	}
+
+	var data map[string]interface{}
+	if response != nil {
+		data = response.Data
+	}
+
+	compiled, err := compileResponseSchema(schema)
+	if err != nil {
+		return fmt.Errorf("failed to compile json schema: %w", err)
+	}
+
+	// jsonschema validates decoded JSON values (map[string]interface{},
+	// []interface{}, json.Number, ...). Round-trip through JSON to normalize
+	// the response data the same way ValidateResponseData does, but only to
+	// normalize types - the structure translated below is what does the
+	// actual validating.
+	normalized, err := roundTripJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to normalize response data: %w", err)
+	}
+
+	if err := compiled.Validate(normalized); err != nil {
+		if ve, ok := err.(*jsonschema.ValidationError); ok {
+			return flattenSchemaViolations(ve, schema)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// compileResponseSchema translates schema into a draft 2020-12 JSON Schema
+// document and compiles it.
+func compileResponseSchema(schema *framework.Response) (*jsonschema.Schema, error) {
+	doc, err := responseToJSONSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json schema: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	// Draft 2019-09+ treats "format" as a non-asserting annotation unless
+	// this is set, which would otherwise make the "date-time" format below
+	// (and any jsonschema: override format keyword) silently unenforced.
+	compiler.AssertFormat = true
+
+	const resourceURL = "testhelpers://response.json"
+	if err := compiler.AddResource(resourceURL, bytes.NewReader(docBytes)); err != nil {
+		return nil, fmt.Errorf("failed to add json schema resource: %w", err)
+	}
+
+	return compiler.Compile(resourceURL)
+}
+
+// responseToJSONSchema is the translation layer between schema.Fields and a
+// draft 2020-12 JSON Schema document. DumpJSONSchema reuses this so the
+// validator and the exported document can never drift apart.
+func responseToJSONSchema(schema *framework.Response) (map[string]interface{}, error) {
+	doc, err := fieldsToJSONSchema(schema.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	doc["$schema"] = jsonSchemaDraft
+
+	return doc, nil
+}
+
+// fieldsToJSONSchema translates a map of framework.FieldSchema, as found on
+// both framework.Response and framework.Path, into the "object" JSON Schema
+// document describing it. DumpJSONSchema reuses this for request bodies so
+// the validator and the exported document can never drift apart.
+func fieldsToJSONSchema(fields map[string]*framework.FieldSchema) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for name, field := range fields {
+		fieldDoc, err := fieldToJSONSchema(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+
+		properties[name] = fieldDoc
+
+		if field.Required {
+			required = append(required, name)
+		}
+	}
+
+	doc := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if len(required) > 0 {
+		// Map iteration order is random; sort so the document is deterministic.
+		sort.Strings(required)
+		doc["required"] = required
+	}
+
+	return doc, nil
+}
+
+// jsonSchemaOverrideAnnotation lets a field declare its own JSON Schema
+// fragment as a trailing "jsonschema:<json object>" annotation on its
+// Description, e.g. "The target hosts. jsonschema:{"type":"array","items":
+// {"type":"string","pattern":"^[a-z0-9.-]+$"}}". framework.FieldSchema has
+// no field for nested object/array item schemas, oneOf/anyOf, or
+// pattern/min-max constraints, so this is the escape hatch for fields whose
+// FieldType alone can't express them - the same Description-annotation
+// convention ValidateResponseWithFormats uses for "format:".
+var jsonSchemaOverrideAnnotation = regexp.MustCompile(`(?s)jsonschema:(\{.*\})\s*$`)
+
+// fieldToJSONSchema translates a single framework.FieldSchema into its JSON
+// Schema equivalent. A field carrying a "jsonschema:" annotation (see
+// jsonSchemaOverrideAnnotation) uses that as is, with AllowedValues added as
+// its "enum" when it declares none; otherwise the schema is
+// inferred from FieldType alone, which for TypeMap and TypeSlice can only
+// produce the most permissive schema that is still true to the declared
+// type, since neither carries a value/item schema.
+func fieldToJSONSchema(field *framework.FieldSchema) (map[string]interface{}, error) {
+	if override, ok, err := fieldJSONSchemaOverride(field); err != nil {
+		return nil, err
+	} else if ok {
+		if _, hasEnum := override["enum"]; !hasEnum && len(field.AllowedValues) > 0 {
+			override["enum"] = field.AllowedValues
+		}
+		return override, nil
+	}
+
+	doc := map[string]interface{}{}
+	if field.Description != "" {
+		doc["description"] = field.Description
+	}
+
+	switch field.Type {
+	case framework.TypeString, framework.TypeNameString, framework.TypeLowerCaseString, framework.TypeHeader:
+		doc["type"] = "string"
+	case framework.TypeInt, framework.TypeInt64, framework.Type64BitUnsignedInteger:
+		doc["type"] = "integer"
+	case framework.TypeFloat:
+		doc["type"] = "number"
+	case framework.TypeBool:
+		doc["type"] = "boolean"
+	case framework.TypeMap:
+		doc["type"] = "object"
+	case framework.TypeKVPairs:
+		// TypeKVPairs always decodes into map[string]string.
+		doc["type"] = "object"
+		doc["additionalProperties"] = map[string]interface{}{"type": "string"}
+	case framework.TypeDurationSecond, framework.TypeSignedDurationSecond:
+		doc["type"] = "integer"
+	case framework.TypeTime:
+		doc["type"] = "string"
+		doc["format"] = "date-time"
+	case framework.TypeSlice:
+		doc["type"] = "array"
+	case framework.TypeStringSlice, framework.TypeCommaStringSlice:
+		doc["type"] = "array"
+		doc["items"] = map[string]interface{}{"type": "string"}
+	case framework.TypeCommaIntSlice:
+		doc["type"] = "array"
+		doc["items"] = map[string]interface{}{"type": "integer"}
+	default:
+		return nil, fmt.Errorf("unsupported field type %q", field.Type)
+	}
+
+	if len(field.AllowedValues) > 0 {
+		doc["enum"] = field.AllowedValues
+	}
+
+	return doc, nil
+}
+
+// fieldJSONSchemaOverride extracts and parses a field's "jsonschema:"
+// annotation, if any. See jsonSchemaOverrideAnnotation.
+func fieldJSONSchemaOverride(field *framework.FieldSchema) (map[string]interface{}, bool, error) {
+	match := jsonSchemaOverrideAnnotation.FindStringSubmatch(field.Description)
+	if match == nil {
+		return nil, false, nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(match[1]), &doc); err != nil {
+		return nil, false, fmt.Errorf("invalid jsonschema: annotation: %w", err)
+	}
+
+	return doc, true, nil
+}
+
+// roundTripJSON marshals v to JSON and back so it is expressed purely in
+// terms of the types encoding/json decodes into (map[string]interface{},
+// []interface{}, json.Number, string, bool, nil), which is what
+// github.com/santhosh-tekuri/jsonschema expects to validate against.
+func roundTripJSON(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var out interface{}
+	if err := decoder.Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// flattenSchemaViolations walks the cause tree jsonschema.ValidationError
+// builds up and flattens it into a ValidationErrors, one entry per leaf
+// violation. schema is consulted to attach a SpecField whenever a violation
+// can be traced back to a single top-level field.
+func flattenSchemaViolations(ve *jsonschema.ValidationError, schema *framework.Response) ValidationErrors {
+	var errs ValidationErrors
+
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+
+		path := e.InstanceLocation
+		if path == "" {
+			path = "/"
+		}
+
+		errs = append(errs, &ValidationError{
+			Path:      path,
+			Keyword:   lastKeyword(e.KeywordLocation),
+			Expected:  e.KeywordLocation,
+			Got:       e.Message,
+			SpecField: schema.Fields[topLevelField(path)],
+		})
+	}
+	walk(ve)
+
+	return errs
+}
+
+// lastKeyword pulls the failing JSON Schema keyword (e.g. "type", "enum")
+// off the end of a jsonschema keyword location such as
+// "/properties/ttl/type".
+func lastKeyword(keywordLocation string) string {
+	if idx := strings.LastIndex(keywordLocation, "/"); idx != -1 {
+		return keywordLocation[idx+1:]
+	}
+	return keywordLocation
+}
+
+// topLevelField extracts the first path segment from a JSON pointer, e.g.
+// "/metadata/owner" becomes "metadata".
+func topLevelField(path string) string {
+	trimmed := strings.TrimPrefix(path, "/")
+	if idx := strings.Index(trimmed, "/"); idx != -1 {
+		return trimmed[:idx]
+	}
+	return trimmed
+}
@@ -0,0 +1,56 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func TestValidateResponseStrictJSONSchema_FormatAssertion(t *testing.T) {
+	schema := &framework.Response{
+		Fields: map[string]*framework.FieldSchema{
+			"expiration": {
+				Type:     framework.TypeTime,
+				Required: true,
+			},
+		},
+	}
+
+	bad := &logical.Response{Data: map[string]interface{}{"expiration": "not-a-timestamp"}}
+	if err := ValidateResponseStrictJSONSchema(schema, bad); err == nil {
+		t.Error("expected a malformed date-time to fail format assertion")
+	}
+
+	good := &logical.Response{Data: map[string]interface{}{"expiration": "2024-01-02T15:04:05Z"}}
+	if err := ValidateResponseStrictJSONSchema(schema, good); err != nil {
+		t.Errorf("expected a valid rfc3339 timestamp to pass, got %v", err)
+	}
+}
+
+func TestValidateResponseStrictJSONSchema_OverrideAnnotation(t *testing.T) {
+	schema := &framework.Response{
+		Fields: map[string]*framework.FieldSchema{
+			"hosts": {
+				Type:        framework.TypeSlice,
+				Required:    true,
+				Description: `The target hosts. jsonschema:{"type":"array","items":{"type":"string","pattern":"^[a-z0-9.-]+$"},"minItems":1}`,
+			},
+		},
+	}
+
+	bad := &logical.Response{Data: map[string]interface{}{"hosts": []interface{}{}}}
+	if err := ValidateResponseStrictJSONSchema(schema, bad); err == nil {
+		t.Error("expected an empty hosts slice to fail the overridden minItems constraint")
+	}
+
+	bad = &logical.Response{Data: map[string]interface{}{"hosts": []interface{}{"NOT VALID!"}}}
+	if err := ValidateResponseStrictJSONSchema(schema, bad); err == nil {
+		t.Error("expected a host violating the overridden pattern to fail")
+	}
+
+	good := &logical.Response{Data: map[string]interface{}{"hosts": []interface{}{"vault.example.com"}}}
+	if err := ValidateResponseStrictJSONSchema(schema, good); err != nil {
+		t.Errorf("expected a conforming hosts slice to pass, got %v", err)
+	}
+}
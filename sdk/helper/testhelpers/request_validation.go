@@ -0,0 +1,66 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// ValidateRequest validates whether the given request's data conforms to the
+// input schema declared by paths[pathIdx] for op, mirroring ValidateResponse
+// on the request side. It cycles through req.Data and validates conversions
+// against the declared framework.FieldSchema for each field, regardless of
+// whether the field is path-captured or a body parameter (FieldSchema.Query
+// distinguishes query-string parameters, which this also validates), and
+// additionally checks any "format:<name>" annotation the same way
+// ValidateResponseWithFormats does on the response side. In "strict" mode,
+// this function will also ensure that req.Data has all schema-required
+// fields and does not have any fields outside of the schema.
+//
+// Every violation found is accumulated before returning: a non-nil error is
+// always a ValidationErrors, the same as ValidateResponseData.
+//
+// This function is inefficient and is intended to be used in tests only.
+func ValidateRequest(t *testing.T, paths []*framework.Path, pathIdx int, op logical.Operation, req *logical.Request, strict bool) error {
+	t.Helper()
+
+	schema := FindRequestSchema(t, paths, pathIdx, op)
+
+	var data map[string]interface{}
+	if req != nil {
+		data = req.Data
+	}
+
+	errs := validateFields(data, schema, strict)
+	errs = append(errs, formatViolations(schema, data)...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// FindRequestSchema is a test helper to extract the request input schema
+// (the fields an operation accepts, whether path-captured or body/query
+// parameters) from a given framework path / operation.
+func FindRequestSchema(t *testing.T, paths []*framework.Path, pathIdx int, operation logical.Operation) map[string]*framework.FieldSchema {
+	t.Helper()
+
+	if pathIdx >= len(paths) {
+		t.Fatalf("path index %d is out of range", pathIdx)
+	}
+
+	schemaPath := paths[pathIdx]
+
+	if _, ok := schemaPath.Operations[operation]; !ok {
+		t.Fatalf(
+			"could not find request schema: %s: %q operation does not exist",
+			schemaPath.Pattern,
+			operation,
+		)
+	}
+
+	return schemaPath.Fields
+}
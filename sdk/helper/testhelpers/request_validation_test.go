@@ -0,0 +1,82 @@
+package testhelpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+func testWidgetPaths() []*framework.Path {
+	noopCallback := func(context.Context, *logical.Request, *framework.FieldData) (*logical.Response, error) {
+		return nil, nil
+	}
+
+	return []*framework.Path{
+		{
+			Pattern: "widgets/.+",
+			Fields: map[string]*framework.FieldSchema{
+				"id": {
+					Type:     framework.TypeString,
+					Required: true,
+				},
+				"owner_email": {
+					Type:        framework.TypeString,
+					Description: "The widget owner's email address. format:email",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback: noopCallback,
+				},
+			},
+		},
+	}
+}
+
+func TestValidateRequest_FormatViolation(t *testing.T) {
+	paths := testWidgetPaths()
+
+	req := &logical.Request{
+		Data: map[string]interface{}{
+			"id":          "abc",
+			"owner_email": "not-an-email",
+		},
+	}
+
+	err := ValidateRequest(t, paths, 0, logical.UpdateOperation, req, false)
+	if err == nil {
+		t.Fatal("expected a malformed owner_email to fail format validation")
+	}
+
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	found := false
+	for _, e := range errs {
+		if e.Path == "/owner_email" && e.Keyword == "format" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an /owner_email format violation, got %v", errs)
+	}
+}
+
+func TestValidateRequest_Valid(t *testing.T) {
+	paths := testWidgetPaths()
+
+	req := &logical.Request{
+		Data: map[string]interface{}{
+			"id":          "abc",
+			"owner_email": "owner@example.com",
+		},
+	}
+
+	if err := ValidateRequest(t, paths, 0, logical.UpdateOperation, req, true); err != nil {
+		t.Errorf("expected a conforming request to pass, got %v", err)
+	}
+}
@@ -31,6 +31,11 @@ func ValidateResponse(schema *framework.Response, response *logical.Response, st
 // also ensure that the data map has all schema-required fields and does not
 // have any fields outside of the schema.
 //
+// Every violation found is accumulated before returning rather than stopping
+// at the first one: apart from a failure to convert data to JSON, a non-nil
+// error is a ValidationErrors, so a single test run surfaces every offending
+// field instead of just one.
+//
 // This function is inefficient and is intended to be used in tests only.
 func ValidateResponseData(schema *framework.Response, data map[string]interface{}, strict bool) error {
 	// nothing to validate
@@ -39,8 +44,8 @@ func ValidateResponseData(schema *framework.Response, data map[string]interface{
 	}
 
 	// Marshal the data to JSON and back to convert the map's values into
-	// JSON strings expected by Validate() and ValidateStrict(). This is
-	// not efficient and is done for testing purposes only.
+	// the JSON-ish types validateFields' underlying framework.FieldData
+	// expects. This is not efficient and is done for testing purposes only.
 	jsonBytes, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to convert input to json: %w", err)
@@ -54,17 +59,11 @@ func ValidateResponseData(schema *framework.Response, data map[string]interface{
 		return fmt.Errorf("failed to unmashal data: %w", err)
 	}
 
-	// Validate
-	fd := framework.FieldData{
-		Raw:    dataWithStringValues,
-		Schema: schema.Fields,
-	}
-
-	if strict {
-		return fd.ValidateStrict()
+	if errs := validateFields(dataWithStringValues, schema.Fields, strict); len(errs) > 0 {
+		return errs
 	}
 
-	return fd.Validate()
+	return nil
 }
 
 // FindResponseSchema is a test helper to extract the response schema from a given framework path / operation
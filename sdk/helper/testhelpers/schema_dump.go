@@ -0,0 +1,82 @@
+package testhelpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+	"github.com/hashicorp/vault/sdk/logical"
+)
+
+// defNameSanitizer replaces every run of characters that can't appear in a
+// JSON Schema $defs key with a single underscore.
+var defNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// DumpJSONSchema walks every operation declared across paths and emits a
+// single draft 2020-12 JSON Schema document describing them, for external
+// linters, contract tests, and generated clients that can't otherwise see
+// inside the framework.Path struct graph. Each path/operation gets a $defs
+// entry keyed by its sanitized pattern and operation, containing a
+// requestBody schema (from framework.Path.Fields, the same fields
+// ValidateRequest checks) and a responses map keyed by HTTP status code
+// (from the same framework.Response schemas ValidateResponse checks).
+// required and additionalProperties:false follow the same rules the
+// validators in this package already enforce in strict mode, and enum is
+// populated from AllowedValues.
+//
+// The output is deterministic - encoding/json sorts object keys
+// lexicographically - so it is safe to golden-test.
+func DumpJSONSchema(paths []*framework.Path) ([]byte, error) {
+	defs := map[string]interface{}{}
+
+	for _, path := range paths {
+		for op, operation := range path.Operations {
+			name := defName(path.Pattern, op)
+			if _, exists := defs[name]; exists {
+				return nil, fmt.Errorf("duplicate $defs key %q for pattern %q operation %q", name, path.Pattern, op)
+			}
+
+			requestBody, err := fieldsToJSONSchema(path.Fields)
+			if err != nil {
+				return nil, fmt.Errorf("%s: request body: %w", name, err)
+			}
+
+			responses := map[string]interface{}{}
+			for status, schemas := range operation.Properties().Responses {
+				if len(schemas) == 0 {
+					continue
+				}
+
+				responseDoc, err := responseToJSONSchema(&schemas[0])
+				if err != nil {
+					return nil, fmt.Errorf("%s: response %d: %w", name, status, err)
+				}
+				delete(responseDoc, "$schema")
+
+				responses[strconv.Itoa(status)] = responseDoc
+			}
+
+			defs[name] = map[string]interface{}{
+				"requestBody": requestBody,
+				"responses":   responses,
+			}
+		}
+	}
+
+	doc := map[string]interface{}{
+		"$schema": jsonSchemaDraft,
+		"$defs":   defs,
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// defName derives a $defs key from a path pattern and operation, e.g.
+// "secret_(?P<id>.+)" + read becomes "secret__P_id-read".
+func defName(pattern string, op logical.Operation) string {
+	sanitized := defNameSanitizer.ReplaceAllString(pattern, "_")
+	return strings.Trim(sanitized, "_") + "-" + string(op)
+}
@@ -0,0 +1,39 @@
+package testhelpers
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDumpJSONSchema_Deterministic(t *testing.T) {
+	paths := testWidgetPaths()
+
+	first, err := DumpJSONSchema(paths)
+	if err != nil {
+		t.Fatalf("DumpJSONSchema: %v", err)
+	}
+
+	second, err := DumpJSONSchema(paths)
+	if err != nil {
+		t.Fatalf("DumpJSONSchema: %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("expected two DumpJSONSchema calls over the same paths to be byte-identical:\n--- first ---\n%s\n--- second ---\n%s", first, second)
+	}
+}
+
+func TestDumpJSONSchema_ContainsDeclaredFields(t *testing.T) {
+	paths := testWidgetPaths()
+
+	doc, err := DumpJSONSchema(paths)
+	if err != nil {
+		t.Fatalf("DumpJSONSchema: %v", err)
+	}
+
+	for _, want := range []string{`"id"`, `"owner_email"`, `"requestBody"`, `"$defs"`} {
+		if !bytes.Contains(doc, []byte(want)) {
+			t.Errorf("expected dumped schema to contain %s:\n%s", want, doc)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package testhelpers
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/vault/sdk/framework"
+)
+
+// ValidationError describes a single violation found while validating
+// request or response data against a schema built from
+// framework.FieldSchema.
+type ValidationError struct {
+	// Path is the JSON pointer to the offending value, e.g. "/ttl".
+	Path string
+	// Keyword identifies what kind of check failed: "type", "required",
+	// "enum", "format", or "additionalProperties", or, for errors from
+	// ValidateResponseStrictJSONSchema, any other failing JSON Schema
+	// keyword such as "minItems" or "pattern".
+	Keyword string
+	// Expected and Got describe the mismatch in human terms.
+	Expected string
+	Got      string
+	// SpecField is the field schema the violation was checked against. It
+	// is nil for keywords, like "additionalProperties", that have no single
+	// matching field.
+	SpecField *framework.FieldSchema
+}
+
+func (e *ValidationError) Error() string {
+	if e.Expected == "" && e.Got == "" {
+		return fmt.Sprintf("%s: %s", e.Path, e.Keyword)
+	}
+	return fmt.Sprintf("%s: %s: expected %s, got %s", e.Path, e.Keyword, e.Expected, e.Got)
+}
+
+// ValidationErrors aggregates every ValidationError a validation run found,
+// instead of surfacing only the first one.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("%d validation errors:\n%s", len(errs), strings.Join(msgs, "\n"))
+}
+
+// Unwrap allows errors.Is/As to reach into individual ValidationErrors.
+func (errs ValidationErrors) Unwrap() []error {
+	out := make([]error, len(errs))
+	for i, e := range errs {
+		out[i] = e
+	}
+	return out
+}
+
+// Format writes a hierarchical, per-field report of errs to w. In verbose
+// mode it also prints each offending field's declared type and
+// description, similar in spirit to how OpenAPI validators print
+// line/column context alongside a keyword failure.
+func (errs ValidationErrors) Format(w io.Writer, verbose bool) {
+	for _, e := range errs {
+		fmt.Fprintf(w, "%s\n", e.Path)
+		fmt.Fprintf(w, "  %s: expected %s, got %s\n", e.Keyword, e.Expected, e.Got)
+
+		if verbose && e.SpecField != nil {
+			fmt.Fprintf(w, "  declared type: %s\n", e.SpecField.Type)
+			if e.SpecField.Description != "" {
+				fmt.Fprintf(w, "  description: %s\n", e.SpecField.Description)
+			}
+		}
+	}
+}
+
+// validateFields is the shared accumulation loop behind ValidateResponseData
+// and ValidateRequest: unlike framework.FieldData.Validate/ValidateStrict,
+// which return as soon as one field fails, it checks every declared field
+// and, in strict mode, that required fields are present and every key in raw
+// is declared, collecting every violation.
+func validateFields(raw map[string]interface{}, schema map[string]*framework.FieldSchema, strict bool) ValidationErrors {
+	var errs ValidationErrors
+
+	fd := &framework.FieldData{Raw: raw, Schema: schema}
+
+	for name, field := range schema {
+		path := "/" + name
+
+		value, ok, err := fd.GetOkErr(name)
+		if err != nil {
+			errs = append(errs, &ValidationError{
+				Path:      path,
+				Keyword:   "type",
+				Expected:  field.Type.String(),
+				Got:       fmt.Sprintf("%T", raw[name]),
+				SpecField: field,
+			})
+			continue
+		}
+
+		if strict && field.Required && !ok {
+			errs = append(errs, &ValidationError{
+				Path:      path,
+				Keyword:   "required",
+				Expected:  "present",
+				Got:       "missing",
+				SpecField: field,
+			})
+			continue
+		}
+
+		if ok && len(field.AllowedValues) > 0 && !isAllowedValue(value, field.AllowedValues) {
+			errs = append(errs, &ValidationError{
+				Path:      path,
+				Keyword:   "enum",
+				Expected:  fmt.Sprintf("%v", field.AllowedValues),
+				Got:       fmt.Sprintf("%v", value),
+				SpecField: field,
+			})
+		}
+	}
+
+	if strict {
+		for name := range raw {
+			if _, ok := schema[name]; !ok {
+				errs = append(errs, &ValidationError{
+					Path:     "/" + name,
+					Keyword:  "additionalProperties",
+					Expected: "no field named " + name,
+					Got:      "present",
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+func isAllowedValue(value interface{}, allowed []interface{}) bool {
+	for _, a := range allowed {
+		if reflect.DeepEqual(value, a) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package testhelpers
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/framework"
+)
+
+func TestValidateResponseData_AccumulatesAllViolations(t *testing.T) {
+	schema := &framework.Response{
+		Fields: map[string]*framework.FieldSchema{
+			"id": {
+				Type:     framework.TypeString,
+				Required: true,
+			},
+			"count": {
+				Type:     framework.TypeInt,
+				Required: true,
+			},
+		},
+	}
+
+	// Both "id" and "count" are missing, and "extra" isn't declared - three
+	// independent violations that must all surface from one call.
+	data := map[string]interface{}{"extra": "nope"}
+
+	err := ValidateResponseData(schema, data, true)
+	if err == nil {
+		t.Fatal("expected violations, got nil")
+	}
+
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 accumulated violations, got %d: %v", len(errs), errs)
+	}
+
+	var sawIDRequired, sawCountRequired, sawExtraAdditional bool
+	for _, e := range errs {
+		switch {
+		case e.Path == "/id" && e.Keyword == "required":
+			sawIDRequired = true
+		case e.Path == "/count" && e.Keyword == "required":
+			sawCountRequired = true
+		case e.Path == "/extra" && e.Keyword == "additionalProperties":
+			sawExtraAdditional = true
+		}
+	}
+	if !sawIDRequired || !sawCountRequired || !sawExtraAdditional {
+		t.Errorf("missing an expected violation: %v", errs)
+	}
+}
+
+func TestValidationErrors_UnwrapAndFormat(t *testing.T) {
+	target := &ValidationError{Path: "/id", Keyword: "required", Expected: "present", Got: "missing"}
+	errs := ValidationErrors{target}
+
+	if !errors.Is(errs, target) {
+		t.Error("expected errors.Is to find the wrapped ValidationError")
+	}
+
+	var buf bytes.Buffer
+	errs.Format(&buf, false)
+	if !strings.Contains(buf.String(), "/id") {
+		t.Errorf("expected Format output to mention the field path, got: %s", buf.String())
+	}
+}